@@ -0,0 +1,444 @@
+// Package api exposes read-only HTTP endpoints over historical game and
+// bet data. Handlers query *sql.DB directly rather than going through a
+// live *game.Game, so they work the same regardless of which (if any)
+// game is currently running.
+package api;
+
+import (
+	"database/sql";
+	"encoding/json";
+	"net/http";
+	"path";
+	"strconv";
+	"time";
+
+	"github.com/google/uuid";
+);
+
+const (
+	DefaultRecentGamesLimit = 20;
+	MaxRecentGamesLimit = 100;
+
+	DefaultWalletBetsLimit = 20;
+	MaxWalletBetsLimit = 100;
+);
+
+var windowDurations = map[string]time.Duration{
+	"24h": 24 * time.Hour,
+	"7d" : 7 * 24 * time.Hour,
+};
+
+type gameSummary struct {
+	Id uuid.UUID;
+	StartTime time.Time;
+	Duration time.Duration;
+	Multiplier string;
+	PlayerCount int;
+	WinnerCount int;
+	GameHash string;
+	ClientSeed string;
+	TotalBetAmount string;
+	TotalUsdValue string;
+};
+
+func (g *gameSummary) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]any{
+		"id"            : g.Id.String(),
+		"startTime"     : g.StartTime.Unix(),
+		"duration"      : g.Duration.Milliseconds(),
+		"multiplier"    : g.Multiplier,
+		"players"       : g.PlayerCount,
+		"winners"       : g.WinnerCount,
+		"gameHash"      : g.GameHash,
+		"clientSeed"    : g.ClientSeed,
+		"totalBetAmount": g.TotalBetAmount,
+		"totalUsdValue" : g.TotalUsdValue,
+	});
+}
+
+func scanGameSummary(row interface{ Scan(...any) error }) (*gameSummary, error) {
+	var g gameSummary;
+	var startTime int64;
+	var durationMs int64;
+
+	err := row.Scan(
+		&g.Id, &startTime, &durationMs, &g.Multiplier,
+		&g.PlayerCount, &g.WinnerCount, &g.GameHash, &g.ClientSeed,
+		&g.TotalBetAmount, &g.TotalUsdValue,
+	);
+
+	if err != nil {
+		return nil, err;
+	}
+
+	g.StartTime = time.Unix(startTime, 0);
+	g.Duration = time.Duration(durationMs) * time.Millisecond;
+
+	return &g, nil;
+}
+
+// RecentGamesHandler serves GET /games/recent?limit=N, returning the most
+// recently completed games.
+func RecentGamesHandler(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := DefaultRecentGamesLimit;
+
+		if raw := r.URL.Query().Get("limit"); raw != "" {
+			parsed, err := strconv.Atoi(raw);
+
+			if err != nil || parsed <= 0 {
+				http.Error(w, "invalid limit", http.StatusBadRequest);
+				return;
+			}
+
+			limit = parsed;
+		}
+
+		if limit > MaxRecentGamesLimit {
+			limit = MaxRecentGamesLimit;
+		}
+
+		rows, err := db.Query(`
+			SELECT id, startTime, (endTime - startTime) AS duration,
+			multiplier, playerCount, winnerCount, gameHash, clientSeed,
+			totalBetAmount, totalUsdValue
+			FROM games
+			ORDER BY startTime DESC
+			LIMIT ?
+		`, limit);
+
+		if err != nil {
+			http.Error(w, "internal error", http.StatusInternalServerError);
+			return;
+		}
+
+		defer rows.Close();
+
+		games := []*gameSummary{};
+
+		for rows.Next() {
+			g, err := scanGameSummary(rows);
+
+			if err != nil {
+				http.Error(w, "internal error", http.StatusInternalServerError);
+				return;
+			}
+
+			games = append(games, g);
+		}
+
+		w.Header().Set("Content-Type", "application/json");
+		json.NewEncoder(w).Encode(games);
+	};
+}
+
+type betDetail struct {
+	Wallet string;
+	Currency string;
+	BetAmount string;
+	CashoutMultiplier sql.NullString;
+	Payout sql.NullString;
+	Auto bool;
+	PlacedAt time.Time;
+};
+
+func (b *betDetail) MarshalJSON() ([]byte, error) {
+	out := map[string]any{
+		"wallet"   : b.Wallet,
+		"currency" : b.Currency,
+		"betAmount": b.BetAmount,
+		"auto"     : b.Auto,
+		"placedAt" : b.PlacedAt.Unix(),
+	};
+
+	if b.CashoutMultiplier.Valid {
+		out["cashoutMultiplier"] = b.CashoutMultiplier.String;
+	}
+
+	if b.Payout.Valid {
+		out["payout"] = b.Payout.String;
+	}
+
+	return json.Marshal(out);
+}
+
+type gameDetailResponse struct {
+	Game *gameSummary `json:"game"`;
+	Bets []*betDetail `json:"bets"`;
+};
+
+// GameDetailHandler serves GET /games/:id, returning a single game along
+// with the bet and cashout detail for every player who took part.
+func GameDetailHandler(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		gameId, err := uuid.Parse(path.Base(r.URL.Path));
+
+		if err != nil {
+			http.Error(w, "invalid game id", http.StatusBadRequest);
+			return;
+		}
+
+		row := db.QueryRow(`
+			SELECT id, startTime, (endTime - startTime) AS duration,
+			multiplier, playerCount, winnerCount, gameHash, clientSeed,
+			totalBetAmount, totalUsdValue
+			FROM games
+			WHERE id = ?
+		`, gameId);
+
+		g, err := scanGameSummary(row);
+
+		if err == sql.ErrNoRows {
+			http.Error(w, "game not found", http.StatusNotFound);
+			return;
+		}
+
+		if err != nil {
+			http.Error(w, "internal error", http.StatusInternalServerError);
+			return;
+		}
+
+		rows, err := db.Query(`
+			SELECT wallet, currency, betAmount, cashoutMultiplier, payout,
+			auto, placedAt
+			FROM bets
+			WHERE gameId = ?
+			ORDER BY placedAt ASC
+		`, gameId);
+
+		if err != nil {
+			http.Error(w, "internal error", http.StatusInternalServerError);
+			return;
+		}
+
+		defer rows.Close();
+
+		bets := []*betDetail{};
+
+		for rows.Next() {
+			var b betDetail;
+
+			err := rows.Scan(
+				&b.Wallet, &b.Currency, &b.BetAmount, &b.CashoutMultiplier,
+				&b.Payout, &b.Auto, &b.PlacedAt,
+			);
+
+			if err != nil {
+				http.Error(w, "internal error", http.StatusInternalServerError);
+				return;
+			}
+
+			bets = append(bets, &b);
+		}
+
+		w.Header().Set("Content-Type", "application/json");
+		json.NewEncoder(w).Encode(gameDetailResponse{ Game: g, Bets: bets });
+	};
+}
+
+type leaderboardEntry struct {
+	Wallet string `json:"wallet"`;
+	Currency string `json:"currency"`;
+	TotalWagered string `json:"totalWagered"`;
+	TotalPayout string `json:"totalPayout"`;
+	BetCount int `json:"betCount"`;
+};
+
+// LeaderboardHandler serves GET /leaderboard?window=24h|7d|all&currency=X,
+// ranking wallets by total payout within the requested window.
+func LeaderboardHandler(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query();
+
+		currency := query.Get("currency");
+
+		if currency == "" {
+			http.Error(w, "currency is required", http.StatusBadRequest);
+			return;
+		}
+
+		window := query.Get("window");
+
+		if window == "" {
+			window = "all";
+		}
+
+		args := []any{ currency };
+		sinceClause := "";
+
+		if window != "all" {
+			duration, ok := windowDurations[window];
+
+			if !ok {
+				http.Error(w, "invalid window", http.StatusBadRequest);
+				return;
+			}
+
+			sinceClause = "AND placedAt >= ?";
+			args = append(args, time.Now().Add(-duration));
+		}
+
+		rows, err := db.Query(`
+			SELECT wallet, currency, SUM(betAmount) AS totalWagered,
+			SUM(COALESCE(payout, 0)) AS totalPayout, COUNT(*) AS betCount
+			FROM bets
+			WHERE currency = ? `+sinceClause+`
+			GROUP BY wallet, currency
+			ORDER BY totalPayout DESC
+			LIMIT 100
+		`, args...);
+
+		if err != nil {
+			http.Error(w, "internal error", http.StatusInternalServerError);
+			return;
+		}
+
+		defer rows.Close();
+
+		entries := []leaderboardEntry{};
+
+		for rows.Next() {
+			var e leaderboardEntry;
+
+			err := rows.Scan(
+				&e.Wallet, &e.Currency, &e.TotalWagered, &e.TotalPayout,
+				&e.BetCount,
+			);
+
+			if err != nil {
+				http.Error(w, "internal error", http.StatusInternalServerError);
+				return;
+			}
+
+			entries = append(entries, e);
+		}
+
+		w.Header().Set("Content-Type", "application/json");
+		json.NewEncoder(w).Encode(entries);
+	};
+}
+
+type walletBet struct {
+	GameId uuid.UUID;
+	Currency string;
+	BetAmount string;
+	CashoutMultiplier sql.NullString;
+	Payout sql.NullString;
+	Auto bool;
+	PlacedAt time.Time;
+};
+
+func (b *walletBet) MarshalJSON() ([]byte, error) {
+	out := map[string]any{
+		"gameId"   : b.GameId.String(),
+		"currency" : b.Currency,
+		"betAmount": b.BetAmount,
+		"auto"     : b.Auto,
+		"placedAt" : b.PlacedAt.Unix(),
+	};
+
+	if b.CashoutMultiplier.Valid {
+		out["cashoutMultiplier"] = b.CashoutMultiplier.String;
+	}
+
+	if b.Payout.Valid {
+		out["payout"] = b.Payout.String;
+	}
+
+	return json.Marshal(out);
+}
+
+// WalletBetsHandler serves GET /wallets/:wallet/bets?cursor=...&limit=N,
+// returning a wallet's bet history ordered newest-first with keyset
+// pagination (cursor is the placedAt unix timestamp of the last row seen).
+func WalletBetsHandler(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		wallet := path.Base(path.Dir(r.URL.Path));
+
+		if wallet == "" || wallet == "." || wallet == "/" {
+			http.Error(w, "invalid wallet", http.StatusBadRequest);
+			return;
+		}
+
+		query := r.URL.Query();
+		limit := DefaultWalletBetsLimit;
+
+		if raw := query.Get("limit"); raw != "" {
+			parsed, err := strconv.Atoi(raw);
+
+			if err != nil || parsed <= 0 {
+				http.Error(w, "invalid limit", http.StatusBadRequest);
+				return;
+			}
+
+			limit = parsed;
+		}
+
+		if limit > MaxWalletBetsLimit {
+			limit = MaxWalletBetsLimit;
+		}
+
+		args := []any{ wallet };
+		cursorClause := "";
+
+		if raw := query.Get("cursor"); raw != "" {
+			cursor, err := strconv.ParseInt(raw, 10, 64);
+
+			if err != nil {
+				http.Error(w, "invalid cursor", http.StatusBadRequest);
+				return;
+			}
+
+			cursorClause = "AND placedAt < ?";
+			args = append(args, time.Unix(cursor, 0));
+		}
+
+		args = append(args, limit);
+
+		rows, err := db.Query(`
+			SELECT gameId, currency, betAmount, cashoutMultiplier, payout,
+			auto, placedAt
+			FROM bets
+			WHERE wallet = ? `+cursorClause+`
+			ORDER BY placedAt DESC
+			LIMIT ?
+		`, args...);
+
+		if err != nil {
+			http.Error(w, "internal error", http.StatusInternalServerError);
+			return;
+		}
+
+		defer rows.Close();
+
+		bets := []*walletBet{};
+
+		for rows.Next() {
+			var b walletBet;
+
+			err := rows.Scan(
+				&b.GameId, &b.Currency, &b.BetAmount, &b.CashoutMultiplier,
+				&b.Payout, &b.Auto, &b.PlacedAt,
+			);
+
+			if err != nil {
+				http.Error(w, "internal error", http.StatusInternalServerError);
+				return;
+			}
+
+			bets = append(bets, &b);
+		}
+
+		var nextCursor string;
+
+		if len(bets) == limit {
+			nextCursor = strconv.FormatInt(bets[len(bets) - 1].PlacedAt.Unix(), 10);
+		}
+
+		w.Header().Set("Content-Type", "application/json");
+		json.NewEncoder(w).Encode(map[string]any{
+			"bets"      : bets,
+			"nextCursor": nextCursor,
+		});
+	};
+}
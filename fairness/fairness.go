@@ -0,0 +1,285 @@
+package fairness;
+
+import (
+	"crypto/hmac";
+	"crypto/rand";
+	"crypto/sha256";
+	"database/sql";
+	"encoding/binary";
+	"encoding/hex";
+	"errors";
+	"math";
+	"sync";
+	"time";
+
+	"github.com/shopspring/decimal";
+);
+
+var (
+	ErrChainExhausted = errors.New("hash chain exhausted; rotation required");
+);
+
+const (
+	DefaultChainLength = 1_000_000;
+	DefaultHouseEdgeDivisor = 33;
+
+	payoutCoeff = 6E-5;
+
+	// Top 52 bits of the HMAC, per the house-edge formula.
+	entropyBits = 52;
+);
+
+// Outcome is the provably-fair result for a single game: the hash consumed
+// from the chain, the client seed it was combined with, and the derived
+// crash point / duration pair.
+type Outcome struct {
+	GameHash string;
+	ClientSeed string;
+	CrashPoint decimal.Decimal;
+	Duration time.Duration;
+	Index int;
+};
+
+// RotationResult is returned when a server seed is retired, revealing it
+// alongside the old and new public commitments.
+type RotationResult struct {
+	OldServerSeed string;
+	OldCommitment string;
+	NewCommitment string;
+};
+
+// HashChainOracle implements a commit-reveal, hash-chain based crash
+// oracle: a random serverSeed is chosen, a chain of SHA-256 hashes is
+// derived from it, and h[0] is published as a commitment before any of
+// the chain is consumed. Games consume h[N], h[N-1], ... down to h[1];
+// anyone can verify a revealed h[k] by hashing it forward k times and
+// checking the result equals the published commitment.
+type HashChainOracle struct {
+	db *sql.DB;
+	chainLength int;
+	houseEdgeDivisor int64;
+
+	mu sync.Mutex;
+	serverSeed []byte;
+	hashes [][32]byte;
+	index int;
+};
+
+func NewHashChainOracle(
+	db *sql.DB,
+	chainLength int,
+	houseEdgeDivisor int64,
+) (*HashChainOracle, error) {
+	if chainLength <= 0 {
+		chainLength = DefaultChainLength;
+	}
+
+	if houseEdgeDivisor <= 0 {
+		houseEdgeDivisor = DefaultHouseEdgeDivisor;
+	}
+
+	oracle := &HashChainOracle{
+		db: db,
+		chainLength: chainLength,
+		houseEdgeDivisor: houseEdgeDivisor,
+	};
+
+	if err := oracle.restoreOrCreate(); err != nil {
+		return nil, err;
+	}
+
+	return oracle, nil;
+}
+
+// Commitment returns the currently published h[0] for the active chain.
+func (oracle *HashChainOracle) Commitment() string {
+	oracle.mu.Lock();
+	defer oracle.mu.Unlock();
+
+	return oracle.commitmentLocked();
+}
+
+func (oracle *HashChainOracle) commitmentLocked() string {
+	return hex.EncodeToString(oracle.hashes[0][:]);
+}
+
+// NextOutcome consumes the next unused hash in the chain and derives a
+// crash outcome from it and the supplied client seed.
+func (oracle *HashChainOracle) NextOutcome(clientSeed string) (Outcome, error) {
+	oracle.mu.Lock();
+	defer oracle.mu.Unlock();
+
+	if oracle.index <= 0 {
+		return Outcome{}, ErrChainExhausted;
+	}
+
+	index := oracle.index;
+	gameHash := oracle.hashes[index];
+
+	oracle.index--;
+
+	_, err := oracle.db.Exec(`
+		UPDATE fairness_chains SET currentIndex = ?
+		WHERE revealedAt IS NULL
+	`, oracle.index);
+
+	if err != nil {
+		return Outcome{}, err;
+	}
+
+	crashPoint := crashPointFromHash(gameHash[:], clientSeed, oracle.houseEdgeDivisor);
+
+	return Outcome{
+		GameHash: hex.EncodeToString(gameHash[:]),
+		ClientSeed: clientSeed,
+		CrashPoint: crashPoint,
+		Duration: durationForCrashPoint(crashPoint),
+		Index: index,
+	}, nil;
+}
+
+// Rotate retires the current server seed, revealing it, and generates a
+// fresh chain with a new public commitment.
+func (oracle *HashChainOracle) Rotate() (RotationResult, error) {
+	oracle.mu.Lock();
+	defer oracle.mu.Unlock();
+
+	oldSeed := hex.EncodeToString(oracle.serverSeed);
+	oldCommitment := oracle.commitmentLocked();
+
+	_, err := oracle.db.Exec(`
+		UPDATE fairness_chains SET revealedAt = ?
+		WHERE revealedAt IS NULL
+	`, time.Now());
+
+	if err != nil {
+		return RotationResult{}, err;
+	}
+
+	if err := oracle.generateChain(); err != nil {
+		return RotationResult{}, err;
+	}
+
+	return RotationResult{
+		OldServerSeed: oldSeed,
+		OldCommitment: oldCommitment,
+		NewCommitment: oracle.commitmentLocked(),
+	}, nil;
+}
+
+func (oracle *HashChainOracle) restoreOrCreate() error {
+	var seedHex string;
+	var currentIndex int;
+	var chainLength int;
+
+	row := oracle.db.QueryRow(`
+		SELECT serverSeed, currentIndex, chainLength FROM fairness_chains
+		WHERE revealedAt IS NULL
+		ORDER BY createdAt DESC
+		LIMIT 1
+	`);
+
+	err := row.Scan(&seedHex, &currentIndex, &chainLength);
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return oracle.generateChain();
+	}
+
+	if err != nil {
+		return err;
+	}
+
+	seed, err := hex.DecodeString(seedHex);
+
+	if err != nil {
+		return err;
+	}
+
+	oracle.serverSeed = seed;
+	oracle.chainLength = chainLength;
+	oracle.hashes = buildChain(seed, oracle.chainLength);
+	oracle.index = currentIndex;
+
+	return nil;
+}
+
+func (oracle *HashChainOracle) generateChain() error {
+	seed := make([]byte, 32);
+
+	if _, err := rand.Read(seed); err != nil {
+		return err;
+	}
+
+	oracle.serverSeed = seed;
+	oracle.hashes = buildChain(seed, oracle.chainLength);
+	oracle.index = oracle.chainLength;
+
+	_, err := oracle.db.Exec(`
+		INSERT INTO fairness_chains
+		(serverSeed, chainLength, currentIndex, commitment, createdAt)
+		VALUES (?, ?, ?, ?, ?)
+	`, hex.EncodeToString(seed), oracle.chainLength, oracle.index,
+		oracle.commitmentLocked(), time.Now());
+
+	return err;
+}
+
+// buildChain derives h[0..length] from serverSeed, where
+// h[length] = SHA256(serverSeed) and h[i] = SHA256(h[i+1]).
+func buildChain(seed []byte, length int) [][32]byte {
+	hashes := make([][32]byte, length + 1);
+	hashes[length] = sha256.Sum256(seed);
+
+	for i := length - 1; i >= 0; i-- {
+		hashes[i] = sha256.Sum256(hashes[i + 1][:]);
+	}
+
+	return hashes;
+}
+
+// crashPointFromHash implements the house-edge formula: HMAC the revealed
+// hash with the client seed, take the top 52 bits as X, and either
+// instant-crash at 1.00x (probability 1/houseEdgeDivisor) or derive the
+// multiplier from X.
+func crashPointFromHash(
+	gameHash []byte,
+	clientSeed string,
+	houseEdgeDivisor int64,
+) decimal.Decimal {
+	mac := hmac.New(sha256.New, gameHash);
+	mac.Write([]byte(clientSeed));
+	sum := mac.Sum(nil);
+
+	x := int64(binary.BigEndian.Uint64(sum[:8]) >> (64 - entropyBits));
+
+	if x % houseEdgeDivisor == 0 {
+		return decimal.NewFromInt(1);
+	}
+
+	const e = int64(1) << entropyBits;
+
+	num := decimal.NewFromInt(100 * e - x);
+	den := decimal.NewFromInt(e - x);
+
+	crashPoint := num.Div(den).Floor().Div(decimal.NewFromInt(100));
+
+	if crashPoint.LessThan(decimal.NewFromInt(1)) {
+		return decimal.NewFromInt(1);
+	}
+
+	return crashPoint;
+}
+
+// durationForCrashPoint is the inverse of Game.calculatePayout: given a
+// target crash multiplier, returns how long the game must run.
+func durationForCrashPoint(crashPoint decimal.Decimal) time.Duration {
+	multiplier, _ := crashPoint.Float64();
+
+	ms := math.Log(multiplier) / payoutCoeff;
+
+	if ms < 0 {
+		ms = 0;
+	}
+
+	return time.Duration(ms * float64(time.Millisecond));
+}
@@ -0,0 +1,78 @@
+package fairness;
+
+import (
+	"crypto/sha256";
+	"math";
+	"testing";
+
+	"github.com/shopspring/decimal";
+);
+
+// crashPointFromHash must be deterministic for a given hash/clientSeed
+// pair, since that's the whole point of a provably-fair scheme: anyone
+// recomputing it from the revealed hash must get the same outcome.
+func TestCrashPointFromHashDeterministic(t *testing.T) {
+	hash := sha256.Sum256([]byte("game-hash-fixture"));
+
+	first := crashPointFromHash(hash[:], "client-seed", DefaultHouseEdgeDivisor);
+	second := crashPointFromHash(hash[:], "client-seed", DefaultHouseEdgeDivisor);
+
+	if !first.Equal(second) {
+		t.Fatalf("expected deterministic crash point, got %s then %s", first, second);
+	}
+}
+
+// crashPointFromHash must never return below 1.00x, the floor for an
+// instant crash.
+func TestCrashPointFromHashNeverBelowOne(t *testing.T) {
+	for i := 0; i < 256; i++ {
+		hash := sha256.Sum256([]byte{byte(i)});
+		crashPoint := crashPointFromHash(hash[:], "seed", DefaultHouseEdgeDivisor);
+
+		if crashPoint.LessThan(decimal.NewFromInt(1)) {
+			t.Fatalf("crash point %s below 1.00x for hash fixture %d", crashPoint, i);
+		}
+	}
+}
+
+// durationForCrashPoint is the stated inverse of Game.calculatePayout;
+// round-tripping a crash point through it and back through the same
+// multiplier formula should land close to the original value.
+func TestDurationForCrashPointRoundTrips(t *testing.T) {
+	crashPoint := decimal.NewFromFloat(2.50);
+
+	duration := durationForCrashPoint(crashPoint);
+
+	durationMs := decimal.NewFromInt(duration.Milliseconds());
+	coeff := decimal.NewFromFloat(payoutCoeff);
+	e := decimal.NewFromFloat(math.Exp(1));
+
+	recovered := e.Pow(coeff.Mul(durationMs));
+
+	diff := recovered.Sub(crashPoint).Abs();
+
+	if diff.GreaterThan(decimal.NewFromFloat(0.01)) {
+		t.Fatalf("round-tripped multiplier %s too far from original %s", recovered, crashPoint);
+	}
+}
+
+func TestBuildChainLinksToCommitment(t *testing.T) {
+	seed := []byte("deterministic-test-seed-000000!");
+	length := 8;
+
+	hashes := buildChain(seed, length);
+
+	if len(hashes) != length + 1 {
+		t.Fatalf("expected %d hashes, got %d", length + 1, len(hashes));
+	}
+
+	if hashes[length] != sha256.Sum256(seed) {
+		t.Fatalf("h[N] must equal SHA256(serverSeed)");
+	}
+
+	for i := length - 1; i >= 0; i-- {
+		if hashes[i] != sha256.Sum256(hashes[i + 1][:]) {
+			t.Fatalf("h[%d] must equal SHA256(h[%d])", i, i + 1);
+		}
+	}
+}
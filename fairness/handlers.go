@@ -0,0 +1,88 @@
+package fairness;
+
+import (
+	"database/sql";
+	"encoding/json";
+	"net/http";
+	"path";
+
+	"github.com/google/uuid";
+);
+
+type verifyResponse struct {
+	GameId string `json:"gameId"`;
+	GameHash string `json:"gameHash"`;
+	ClientSeed string `json:"clientSeed"`;
+	CrashPoint string `json:"crashPoint"`;
+};
+
+type rotateResponse struct {
+	OldServerSeed string `json:"oldServerSeed"`;
+	OldCommitment string `json:"oldCommitment"`;
+	NewCommitment string `json:"newCommitment"`;
+};
+
+type rotator interface {
+	Rotate() (RotationResult, error);
+};
+
+// VerifyHandler serves GET /fairness/verify/:gameId, returning the
+// revealed hash, client seed and crash point for a finished game so
+// players can independently recompute the outcome.
+func VerifyHandler(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		gameId, err := uuid.Parse(path.Base(r.URL.Path));
+
+		if err != nil {
+			http.Error(w, "invalid game id", http.StatusBadRequest);
+			return;
+		}
+
+		var resp verifyResponse;
+
+		resp.GameId = gameId.String();
+
+		row := db.QueryRow(`
+			SELECT gameHash, clientSeed, multiplier
+			FROM games
+			WHERE id = ?
+		`, gameId);
+
+		err = row.Scan(&resp.GameHash, &resp.ClientSeed, &resp.CrashPoint);
+
+		if err == sql.ErrNoRows {
+			http.Error(w, "game not found", http.StatusNotFound);
+			return;
+		}
+
+		if err != nil {
+			http.Error(w, "internal error", http.StatusInternalServerError);
+			return;
+		}
+
+		w.Header().Set("Content-Type", "application/json");
+		json.NewEncoder(w).Encode(resp);
+	};
+}
+
+// RotateHandler serves /fairness/rotate, which retires the current server
+// seed (revealing it) and publishes a new commitment. The handler itself
+// performs no authentication or authorization and does not check the
+// request method: any request that reaches it rotates the seed.
+func RotateHandler(oracle rotator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		result, err := oracle.Rotate();
+
+		if err != nil {
+			http.Error(w, "internal error", http.StatusInternalServerError);
+			return;
+		}
+
+		w.Header().Set("Content-Type", "application/json");
+		json.NewEncoder(w).Encode(rotateResponse{
+			OldServerSeed: result.OldServerSeed,
+			OldCommitment: result.OldCommitment,
+			NewCommitment: result.NewCommitment,
+		});
+	};
+}
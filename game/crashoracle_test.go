@@ -0,0 +1,86 @@
+package game;
+
+import (
+	"testing";
+	"time";
+
+	"github.com/shopspring/decimal";
+
+	"github.com/samott/crash-backend/fairness";
+);
+
+// fakeCrashOracle lets tests inject deterministic outcomes instead of
+// depending on HashChainOracle's real hash chain, per the CrashOracle
+// interface's stated purpose.
+type fakeCrashOracle struct {
+	outcome fairness.Outcome;
+	commitment string;
+};
+
+func (o *fakeCrashOracle) NextOutcome(clientSeed string) (fairness.Outcome, error) {
+	outcome := o.outcome;
+	outcome.ClientSeed = clientSeed;
+
+	return outcome, nil;
+}
+
+func (o *fakeCrashOracle) Commitment() string {
+	return o.commitment;
+}
+
+func (o *fakeCrashOracle) Rotate() (fairness.RotationResult, error) {
+	return fairness.RotationResult{}, nil;
+}
+
+// TestCreateNewGameLockedUsesInjectedOutcome verifies the actual stated
+// purpose of the CrashOracle interface: wiring a fake into Game lets a
+// test assert on a deterministic outcome, rather than just asserting the
+// fake echoes back what it was given.
+func TestCreateNewGameLockedUsesInjectedOutcome(t *testing.T) {
+	db := openFakeDB(t);
+
+	oracle := &fakeCrashOracle{
+		outcome: fairness.Outcome{
+			GameHash: "deadbeef",
+			CrashPoint: decimal.NewFromFloat(2.5),
+			Duration: 3 * time.Second,
+		},
+		commitment: "commitment-hash",
+	};
+
+	game := &Game{
+		db: db,
+		oracle: oracle,
+		id: mustUUID(t),
+		players: []*Player{},
+		waiting: []*Player{},
+	};
+
+	game.createNewGameLocked();
+
+	if game.state != GAMESTATE_WAITING {
+		t.Fatalf("expected state WAITING, got %d", game.state);
+	}
+
+	if game.gameHash != "deadbeef" {
+		t.Fatalf("expected gameHash from the injected outcome, got %q", game.gameHash);
+	}
+
+	if !game.crashPoint.Equal(decimal.NewFromFloat(2.5)) {
+		t.Fatalf("expected crashPoint 2.5 from the injected outcome, got %s", game.crashPoint);
+	}
+
+	if game.duration != 3 * time.Second {
+		t.Fatalf("expected duration 3s from the injected outcome, got %s", game.duration);
+	}
+
+	if game.endTime.Sub(game.startTime) != game.duration {
+		t.Fatalf("expected endTime to be startTime + duration");
+	}
+
+	// The very first game has no prior gameHash to chain from, so the
+	// client seed must fall back to the oracle's published commitment.
+	if game.clientSeed != "commitment-hash" {
+		t.Fatalf("expected clientSeed to fall back to the oracle's commitment, got %q", game.clientSeed);
+	}
+}
@@ -7,9 +7,7 @@ import (
 
 	"slices"
 	"errors"
-
-	"math/big"
-	"crypto/rand"
+	"sync"
 
 	"log/slog"
 
@@ -20,6 +18,7 @@ import (
 	"github.com/zishang520/socket.io/v2/socket"
 
 	"github.com/samott/crash-backend/config"
+	"github.com/samott/crash-backend/fairness"
 );
 
 var (
@@ -27,6 +26,7 @@ var (
 	ErrWrongGameState = errors.New("action invalid for current game state")
 	ErrPlayerNotWaiting = errors.New("player not in waiting list")
 	ErrAlreadyCashedOut = errors.New("player already cashed out")
+	ErrBetOutsideUsdLimits = errors.New("bet USD value outside configured limits")
 )
 
 const WAIT_TIME_SECS = 5;
@@ -36,6 +36,13 @@ const (
 	GAMESTATE_WAITING = iota;
 	GAMESTATE_RUNNING = iota;
 	GAMESTATE_CRASHED = iota;
+	// GAMESTATE_PENDING marks the brief gap between commitWaiting
+	// reserving the next game's id (with its rolled-over bets already
+	// debited) and createNewGameLocked assigning it a real
+	// startTime/duration/crashPoint WAIT_TIME_SECS later. resumeGameState
+	// treats it as "re-run createNewGameLocked", never as settled/terminal,
+	// so a restart in that gap can't orphan the rolled-over bets.
+	GAMESTATE_PENDING = iota;
 	GAMESTATE_INVALID = iota;
 );
 
@@ -43,10 +50,14 @@ const (
 	EVENT_GAME_WAITING = "GameWaiting";
 	EVENT_GAME_RUNNING = "GameRunning";
 	EVENT_GAME_CRASHED = "GameCrashed";
+	EVENT_GAME_TICK    = "GameTick";
+	EVENT_GAME_RESUMED = "GameResumed";
 	EVENT_PLAYER_WON   = "PlayerWon";
 	EVENT_PLAYER_LOST  = "PlayerLost";
 );
 
+const DEFAULT_TICK_INTERVAL_MS = 100;
+
 type Bank interface {
 	IncreaseBalance(
 		string,
@@ -64,11 +75,36 @@ type Bank interface {
 		uuid.UUID,
 	) (decimal.Decimal, error);
 
+	RefundBet(
+		string,
+		string,
+		decimal.Decimal,
+		string,
+		uuid.UUID,
+	) (decimal.Decimal, error);
+
 	GetBalance(string, string) (decimal.Decimal, error);
 
 	GetBalances(wallet string) (map[string]decimal.Decimal, error);
 };
 
+// CrashOracle supplies provably-fair game outcomes. It is satisfied by
+// *fairness.HashChainOracle; tests can provide a fake to inject
+// deterministic outcomes.
+type CrashOracle interface {
+	NextOutcome(clientSeed string) (fairness.Outcome, error);
+	Commitment() string;
+	Rotate() (fairness.RotationResult, error);
+};
+
+// PriceOracle supplies the current USD price of a currency, letting
+// Game normalise bets across assets with wildly different unit prices.
+// It is satisfied by *priceoracle.CryptoCompareOracle; tests can
+// substitute priceoracle.StaticOracle for deterministic prices.
+type PriceOracle interface {
+	GetPrice(currency string) (decimal.Decimal, error);
+};
+
 type CashOut struct {
 	absTime time.Time;
 	duration time.Duration;
@@ -86,6 +122,13 @@ type Player struct {
 	wallet string;
 	clientId socket.SocketId;
 	timeOut *time.Timer;
+	usdValue decimal.Decimal;
+	// debited is true once the player's balance has actually been taken
+	// for this bet, so refund paths don't credit money that was never
+	// deducted (e.g. a bet placed directly during GAMESTATE_WAITING is
+	// debited up front; a rollover bet isn't debited until commitWaiting
+	// moves it from waiting into players).
+	debited bool;
 };
 
 type Observer struct {
@@ -94,6 +137,12 @@ type Observer struct {
 };
 
 type Game struct {
+	// mu guards every field below against concurrent access from socket
+	// handlers, game timers and the tick goroutine. Methods invoked
+	// directly as a goroutine entry point (exported Handle* methods,
+	// timer callbacks, emitTick) must take it; internal helpers assume
+	// the caller already holds it.
+	mu sync.Mutex;
 	id uuid.UUID;
 	state uint;
 	players []*Player;
@@ -103,9 +152,15 @@ type Game struct {
 	db *sql.DB;
 	config *config.CrashConfig;
 	bank Bank;
+	oracle CrashOracle;
+	prices PriceOracle;
 	startTime time.Time;
 	endTime time.Time;
 	duration time.Duration;
+	gameHash string;
+	clientSeed string;
+	crashPoint decimal.Decimal;
+	tickerDone chan struct{};
 };
 
 type CrashedGame struct {
@@ -115,6 +170,10 @@ type CrashedGame struct {
 	multiplier decimal.Decimal;
 	players int;
 	winners int;
+	gameHash string;
+	clientSeed string;
+	totalBetAmount decimal.Decimal;
+	totalUsdValue decimal.Decimal;
 }
 
 func (p *Player) MarshalJSON() ([]byte, error) {
@@ -123,17 +182,22 @@ func (p *Player) MarshalJSON() ([]byte, error) {
 		"currency"   : p.currency,
 		"autoCashOut": p.currency,
 		"wallet"     : p.wallet,
+		"usdValue"   : p.usdValue.String(),
 	});
 }
 
 func (g *CrashedGame) MarshalJSON() ([]byte, error) {
 	return json.Marshal(map[string]any{
-		"id"         : g.id.String(),
-		"startTime"  : g.startTime.Unix(),
-		"duration"   : g.duration.Milliseconds(),
-		"multiplier" : g.multiplier.String(),
-		"players"    : g.players,
-		"winners"    : g.winners,
+		"id"            : g.id.String(),
+		"startTime"     : g.startTime.Unix(),
+		"duration"      : g.duration.Milliseconds(),
+		"multiplier"    : g.multiplier.String(),
+		"players"       : g.players,
+		"winners"       : g.winners,
+		"gameHash"      : g.gameHash,
+		"clientSeed"    : g.clientSeed,
+		"totalBetAmount": g.totalBetAmount.String(),
+		"totalUsdValue" : g.totalUsdValue.String(),
 	});
 }
 
@@ -142,6 +206,8 @@ func NewGame(
 	db *sql.DB,
 	config *config.CrashConfig,
 	bank Bank,
+	oracle CrashOracle,
+	prices PriceOracle,
 ) (*Game, error) {
 	gameId, err := uuid.NewV7();
 
@@ -149,41 +215,72 @@ func NewGame(
 		return nil, err;
 	}
 
-	return &Game{
+	game := &Game{
 		id: gameId,
 		io: io,
 		db: db,
 		config: config,
 		bank: bank,
+		oracle: oracle,
+		prices: prices,
 		observers: make(map[socket.SocketId]*Observer),
 		players: make([]*Player, 0),
 		waiting: make([]*Player, 0),
-	}, nil;
+	};
+
+	if err := game.resumeGameState(); err != nil {
+		slog.Error("Failed to resume in-flight game state", "err", err);
+	}
+
+	return game, nil;
 }
 
 func (game *Game) GetConfig() (*config.CrashConfig) {
 	return game.config;
 }
 
+// createNewGame is the goroutine-entry wrapper used by time.AfterFunc;
+// it takes game.mu before handing off to createNewGameLocked. Callers
+// that already hold game.mu (HandleConnect) must call
+// createNewGameLocked directly instead, to avoid deadlocking on the
+// non-reentrant mutex.
 func (game *Game) createNewGame() {
-	randInt, err := rand.Int(rand.Reader, big.NewInt(10));
+	game.mu.Lock();
+	defer game.mu.Unlock();
 
-	if err != nil {
-		return;
+	game.createNewGameLocked();
+}
+
+// createNewGameLocked assumes game.id already holds the id to play under:
+// either the fresh id assigned at construction (very first game) or the
+// id commitWaiting reserved and persisted when rolling waiting bets over
+// from the game that just crashed. It never generates its own id, so the
+// game_state row written for the rolled-over bets stays valid throughout.
+func (game *Game) createNewGameLocked() {
+	// The client seed is the previous game's revealed hash, so the whole
+	// sequence forms a publicly auditable chain; the very first game
+	// falls back to the chain's published commitment.
+	clientSeed := game.gameHash;
+
+	if clientSeed == "" {
+		clientSeed = game.oracle.Commitment();
 	}
 
-	gameId, err := uuid.NewV7();
+	outcome, err := game.oracle.NextOutcome(clientSeed);
 
 	if err != nil {
+		slog.Error("Failed to derive game outcome", "err", err);
 		return;
 	}
 
-	game.id = gameId;
 	game.state = GAMESTATE_WAITING;
+	game.gameHash = outcome.GameHash;
+	game.clientSeed = outcome.ClientSeed;
+	game.crashPoint = outcome.CrashPoint;
 
 	untilStart := time.Second * WAIT_TIME_SECS;
 	game.startTime = time.Now().Add(untilStart);
-	game.duration = time.Duration(time.Second * time.Duration(randInt.Int64()));
+	game.duration = outcome.Duration;
 	game.endTime = game.startTime.Add(game.duration);
 
 	time.AfterFunc(untilStart, game.handleGameStart);
@@ -197,14 +294,23 @@ func (game *Game) createNewGame() {
 		game.startTime,
 		"endTime",
 		game.endTime,
+		"crashPoint",
+		game.crashPoint,
 	);
 
+	if err := game.persistGameState(); err != nil {
+		slog.Error("Failed to persist game state", "game", game.id, "err", err);
+	}
+
 	game.Emit(EVENT_GAME_WAITING, map[string]any{
 		"startTime": game.startTime.Unix(),
 	});
 }
 
 func (game *Game) handleGameStart() {
+	game.mu.Lock();
+	defer game.mu.Unlock();
+
 	slog.Info("Preparing to start game...", "game", game.id);
 
 	if len(game.observers) == 0 {
@@ -217,27 +323,47 @@ func (game *Game) handleGameStart() {
 
 	game.state = GAMESTATE_RUNNING;
 
-	makeCallback := func(player *Player) func() {
-		return func() {
-			slog.Info("Auto cashing out", "wallet", player.wallet);
-			game.handleCashOut(player.wallet, true);
-		}
-	};
-
 	for i := range(game.players) {
 		if !game.players[i].autoCashOut.Equal(decimal.Zero) {
-			autoCashOut, _ := game.players[i].autoCashOut.Float64();
-			timeOut := time.Duration(float64(time.Millisecond) * math.Log(autoCashOut) / 6E-5);
-			game.players[i].timeOut = time.AfterFunc(timeOut, makeCallback(game.players[i]));
+			game.players[i].timeOut = time.AfterFunc(
+				game.autoCashOutDelay(game.players[i].autoCashOut),
+				game.autoCashOutCallback(game.players[i]),
+			);
 		}
 	}
 
 	game.Emit(EVENT_GAME_RUNNING, map[string]any{
 		"startTime": game.startTime.Unix(),
 	});
+
+	if err := game.persistGameState(); err != nil {
+		slog.Error("Failed to persist game state", "game", game.id, "err", err);
+	}
+
+	game.startTicker();
+}
+
+// autoCashOutDelay returns how long after startTime the multiplier
+// reaches target, i.e. the inverse of multiplierForDuration.
+func (game *Game) autoCashOutDelay(target decimal.Decimal) time.Duration {
+	multiplier, _ := target.Float64();
+
+	return time.Duration(float64(time.Millisecond) * math.Log(multiplier) / 6E-5);
+}
+
+// autoCashOutCallback builds the timer callback that cashes a player out
+// once their auto-cashout target is reached.
+func (game *Game) autoCashOutCallback(player *Player) func() {
+	return func() {
+		slog.Info("Auto cashing out", "wallet", player.wallet);
+		game.handleCashOut(player.wallet, true);
+	}
 }
 
 func (game *Game) handleGameCrash() {
+	game.mu.Lock();
+	defer game.mu.Unlock();
+
 	slog.Info("Crashing game...", "game", game.id);
 
 	game.state = GAMESTATE_CRASHED;
@@ -248,6 +374,8 @@ func (game *Game) handleGameCrash() {
 		});
 	}
 
+	crashedId := game.id;
+
 	record, err := game.saveRecord();
 
 	if err != nil {
@@ -261,6 +389,10 @@ func (game *Game) handleGameCrash() {
 	game.clearTimers();
 	game.commitWaiting();
 
+	if err := game.clearGameState(crashedId); err != nil {
+		slog.Error("Failed to clear settled game state", "game", crashedId, "err", err);
+	}
+
 	game.Emit(EVENT_GAME_CRASHED, map[string]*CrashedGame{
 		"game": record,
 	});
@@ -275,6 +407,9 @@ func (game *Game) HandlePlaceBet(
 	betAmount decimal.Decimal,
 	autoCashOut decimal.Decimal,
 ) error {
+	game.mu.Lock();
+	defer game.mu.Unlock();
+
 	player := Player{
 		wallet: wallet,
 		betAmount: betAmount,
@@ -314,14 +449,54 @@ func (game *Game) HandlePlaceBet(
 		return err;
 	}
 
+	if usdValue, ok := game.usdValueOf(currency, betAmount); ok {
+		player.usdValue = usdValue;
+
+		minUsd := decimal.NewFromFloat(game.config.MinBetUSD);
+		maxUsd := decimal.NewFromFloat(game.config.MaxBetUSD);
+
+		if usdValue.LessThan(minUsd) || usdValue.GreaterThan(maxUsd) {
+			slog.Warn(
+				"Bet rejected for falling outside USD limits",
+				"wallet",
+				wallet,
+				"usdValue",
+				usdValue,
+			);
+
+			return ErrBetOutsideUsdLimits;
+		}
+	}
+
 	if game.state == GAMESTATE_WAITING {
+		if _, err := game.bank.DecreaseBalance(
+			wallet,
+			currency,
+			betAmount,
+			"Bet placed",
+			game.id,
+		); err != nil {
+			slog.Warn("Unable to take balance for user", "wallet", wallet, "err", err);
+			return err;
+		}
+
+		player.debited = true;
+
 		game.players = append(game.players, &player);
+
+		if err := game.recordBetPlaced(&player); err != nil {
+			slog.Error("Failed to record bet", "wallet", player.wallet, "err", err);
+		}
 	} else if (game.state == GAMESTATE_RUNNING) {
 		game.waiting = append(game.waiting, &player);
 	} else {
 		return ErrWrongGameState;
 	}
 
+	if err := game.persistGameState(); err != nil {
+		slog.Error("Failed to persist game state", "game", game.id, "err", err);
+	}
+
 	game.Emit("BetList", map[string]any{
 		"players": game.players,
 		"waiting": game.waiting,
@@ -330,7 +505,29 @@ func (game *Game) HandlePlaceBet(
 	return nil;
 }
 
+// usdValueOf converts amount into its USD equivalent using the
+// configured PriceOracle. It degrades gracefully: if no oracle is
+// configured or the upstream is unavailable, it logs a warning and
+// returns ok=false so callers can skip USD-denominated checks.
+func (game *Game) usdValueOf(currency string, amount decimal.Decimal) (decimal.Decimal, bool) {
+	if game.prices == nil {
+		return decimal.Decimal{}, false;
+	}
+
+	price, err := game.prices.GetPrice(currency);
+
+	if err != nil {
+		slog.Warn("USD price unavailable; skipping USD check", "currency", currency, "err", err);
+		return decimal.Decimal{}, false;
+	}
+
+	return amount.Mul(price), true;
+}
+
 func (game *Game) HandleCancelBet(wallet string) error {
+	game.mu.Lock();
+	defer game.mu.Unlock();
+
 	playerIndex := slices.IndexFunc(game.players, func(p *Player) bool {
 		return p.wallet == wallet;
 	});
@@ -349,6 +546,9 @@ func (game *Game) HandleCashOut(wallet string) error {
 }
 
 func (game *Game) handleCashOut(wallet string, auto bool) error {
+	game.mu.Lock();
+	defer game.mu.Unlock();
+
 	if game.state != GAMESTATE_RUNNING {
 		return ErrWrongGameState;
 	}
@@ -414,6 +614,10 @@ func (game *Game) handleCashOut(wallet string, auto bool) error {
 		);
 	}
 
+	if err := game.recordBetCashedOut(player); err != nil {
+		slog.Error("Failed to record cashout", "wallet", player.wallet, "err", err);
+	}
+
 	observer, ok := game.observers[player.clientId];
 
 	if ok && observer.socket.Connected() {
@@ -423,6 +627,10 @@ func (game *Game) handleCashOut(wallet string, auto bool) error {
 		});
 	}
 
+	if err := game.persistGameState(); err != nil {
+		slog.Error("Failed to persist game state", "game", game.id, "err", err);
+	}
+
 	game.Emit("BetList", map[string]any{
 		"players": game.players,
 		"waiting": game.waiting,
@@ -432,6 +640,9 @@ func (game *Game) handleCashOut(wallet string, auto bool) error {
 }
 
 func (game *Game) HandleConnect(client *socket.Socket) {
+	game.mu.Lock();
+	defer game.mu.Unlock();
+
 	_, exists := game.observers[client.Id()];
 
 	if exists {
@@ -447,7 +658,7 @@ func (game *Game) HandleConnect(client *socket.Socket) {
 
 	if game.state == GAMESTATE_STOPPED {
 		slog.Info("Entering game wait state...");
-		game.createNewGame();
+		game.createNewGameLocked();
 
 		return;
 	}
@@ -459,9 +670,21 @@ func (game *Game) HandleConnect(client *socket.Socket) {
 
 		return;
 	}
+
+	if game.state == GAMESTATE_RUNNING {
+		observer.socket.Emit(EVENT_GAME_RESUMED, map[string]any{
+			"startTime": game.startTime.Unix(),
+			"endTime": game.endTime.Unix(),
+		});
+
+		return;
+	}
 }
 
 func (game *Game) HandleLogin(client *socket.Socket, wallet string) {
+	game.mu.Lock();
+	defer game.mu.Unlock();
+
 	observer, exists := game.observers[client.Id()];
 
 	if !exists {
@@ -476,12 +699,24 @@ func (game *Game) HandleLogin(client *socket.Socket, wallet string) {
 		return;
 	}
 
-	observer.socket.Emit("balanceInit", map[string]map[string]decimal.Decimal{
+	balancesUsd := decimal.Zero;
+
+	for currency, balance := range balances {
+		if usdValue, ok := game.usdValueOf(currency, balance); ok {
+			balancesUsd = balancesUsd.Add(usdValue);
+		}
+	}
+
+	observer.socket.Emit("balanceInit", map[string]any{
 		"balances" : balances,
+		"balancesUsd": balancesUsd.String(),
 	});
 }
 
 func (game *Game) HandleDisconnect(client *socket.Socket) {
+	game.mu.Lock();
+	defer game.mu.Unlock();
+
 	_, exists := game.observers[client.Id()];
 
 	if !exists {
@@ -492,6 +727,8 @@ func (game *Game) HandleDisconnect(client *socket.Socket) {
 }
 
 func (game *Game) clearTimers() {
+	game.stopTicker();
+
 	for i := range(game.players) {
 		if game.players[i].timeOut != nil {
 			game.players[i].timeOut.Stop();
@@ -500,7 +737,101 @@ func (game *Game) clearTimers() {
 	}
 }
 
+// startTicker begins emitting GameTick events at config.TickIntervalMs
+// (or DEFAULT_TICK_INTERVAL_MS if unset) until stopTicker is called. The
+// underlying time.Ticker naturally coalesces emits for slow consumers:
+// if emitTick is still running when the next tick fires, the tick is
+// dropped rather than queued.
+func (game *Game) startTicker() {
+	interval := time.Duration(game.config.TickIntervalMs) * time.Millisecond;
+
+	if interval <= 0 {
+		interval = DEFAULT_TICK_INTERVAL_MS * time.Millisecond;
+	}
+
+	done := make(chan struct{});
+	game.tickerDone = done;
+
+	go func() {
+		ticker := time.NewTicker(interval);
+		defer ticker.Stop();
+
+		for {
+			select {
+			case <-ticker.C:
+				game.emitTick();
+			case <-done:
+				return;
+			}
+		}
+	}();
+}
+
+func (game *Game) stopTicker() {
+	if game.tickerDone == nil {
+		return;
+	}
+
+	close(game.tickerDone);
+	game.tickerDone = nil;
+}
+
+// emitTick sends every observer a single GameTick frame carrying the
+// shared elapsed time and multiplier; an observer who is also an active
+// (not yet cashed out) player gets those same fields merged with their
+// own wallet and unrealized payout, rather than a second, differently
+// shaped frame on the same event.
+func (game *Game) emitTick() {
+	game.mu.Lock();
+	defer game.mu.Unlock();
+
+	elapsed := time.Since(game.startTime);
+	multiplier := game.multiplierForDuration(elapsed);
+	serverTime := time.Now().Unix();
+
+	for _, observer := range game.observers {
+		if !observer.socket.Connected() {
+			continue;
+		}
+
+		payload := map[string]any{
+			"elapsedMs": elapsed.Milliseconds(),
+			"multiplier": multiplier.String(),
+			"serverTime": serverTime,
+		};
+
+		if observer.wallet != "" {
+			playerIndex := slices.IndexFunc(game.players, func(p *Player) bool {
+				return p.wallet == observer.wallet && !p.cashOut.cashedOut;
+			});
+
+			if playerIndex != -1 {
+				player := game.players[playerIndex];
+
+				payload["wallet"] = player.wallet;
+				payload["unrealizedPayout"] = player.betAmount.Mul(multiplier).String();
+			}
+		}
+
+		observer.socket.Emit(EVENT_GAME_TICK, payload);
+	}
+}
+
+// commitWaiting rolls the waiting list over into game.players for the
+// next round and persists that as the in-flight state for a brand new
+// game id, reserved here rather than reused from the game that just
+// crashed. Reusing the old id would mean the caller's subsequent
+// clearGameState(oldId) call deletes the very row this writes, leaving
+// already-debited rollover bets with no persisted record at all.
 func (game *Game) commitWaiting() {
+	newId, err := uuid.NewV7();
+
+	if err != nil {
+		slog.Error("Failed to reserve id for next game", "err", err);
+		return;
+	}
+
+	game.id = newId;
 	game.players = []*Player{};
 
 	for i := range(game.waiting) {
@@ -521,85 +852,470 @@ func (game *Game) commitWaiting() {
 			continue;
 		}
 
+		game.waiting[i].debited = true;
 		game.players = append(game.players, game.waiting[i]);
+
+		if err := game.recordBetPlaced(game.waiting[i]); err != nil {
+			slog.Error("Failed to record bet", "wallet", game.waiting[i].wallet, "err", err);
+		}
 	}
 
 	game.waiting = []*Player{};
+
+	// Marked PENDING rather than left at the caller's CRASHED: this row
+	// now describes the *new* id's rolled-over bets, not the round that
+	// just ended, and must not be mistaken for a settled/terminal game if
+	// the process restarts before createNewGameLocked gives it a real
+	// startTime/duration.
+	game.state = GAMESTATE_PENDING;
+
+	if err := game.persistGameState(); err != nil {
+		slog.Error("Failed to persist game state", "game", game.id, "err", err);
+	}
 }
 
-func (game *Game) calculatePayout(
-	duration time.Duration,
-	betAmount decimal.Decimal,
-) (decimal.Decimal, decimal.Decimal) {
-	durationMs := decimal.NewFromInt(duration.Milliseconds());
-	coeff := decimal.NewFromFloat(6E-5);
-	e := decimal.NewFromFloat(math.Exp(1));
-	multiplier := e.Pow(coeff.Mul(durationMs)).Truncate(2);
+// persistGameState transactionally snapshots the current game and its
+// players/waiting list into game_state / game_state_players, so a
+// restart can find and resume (or settle) whatever was in flight.
+func (game *Game) persistGameState() error {
+	tx, err := game.db.Begin();
 
-	return betAmount.Mul(multiplier), multiplier;
+	if err != nil {
+		return err;
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO game_state
+		(gameId, state, startTime, endTime, crashMultiplier, gameHash, clientSeed)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (gameId) DO UPDATE SET
+		state = excluded.state,
+		startTime = excluded.startTime,
+		endTime = excluded.endTime,
+		crashMultiplier = excluded.crashMultiplier,
+		gameHash = excluded.gameHash,
+		clientSeed = excluded.clientSeed
+	`, game.id, game.state, game.startTime, game.endTime,
+		game.crashPoint, game.gameHash, game.clientSeed);
+
+	if err != nil {
+		tx.Rollback();
+		return err;
+	}
+
+	if _, err := tx.Exec(`DELETE FROM game_state_players WHERE gameId = ?`, game.id); err != nil {
+		tx.Rollback();
+		return err;
+	}
+
+	if err := game.persistGameStatePlayers(tx, game.players, false); err != nil {
+		tx.Rollback();
+		return err;
+	}
+
+	if err := game.persistGameStatePlayers(tx, game.waiting, true); err != nil {
+		tx.Rollback();
+		return err;
+	}
+
+	return tx.Commit();
 }
 
-func (game *Game) calculateFinalMultiplier() (decimal.Decimal) {
-	duration := game.endTime.Sub(game.startTime);
-	durationMs := decimal.NewFromInt(duration.Milliseconds());
-	coeff := decimal.NewFromFloat(6E-5);
-	e := decimal.NewFromFloat(math.Exp(1));
-	multiplier := e.Pow(coeff.Mul(durationMs)).Truncate(2);
-	return multiplier;
+func (game *Game) persistGameStatePlayers(tx *sql.Tx, players []*Player, waiting bool) error {
+	for _, player := range players {
+		_, err := tx.Exec(`
+			INSERT INTO game_state_players
+			(gameId, wallet, currency, betAmount, autoCashOut, waiting,
+			cashedOut, cashoutMultiplier, cashoutPayout, auto)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`, game.id, player.wallet, player.currency, player.betAmount,
+			player.autoCashOut, waiting, player.cashOut.cashedOut,
+			player.cashOut.multiplier, player.cashOut.payout, player.cashOut.auto);
+
+		if err != nil {
+			return err;
+		}
+	}
+
+	return nil;
+}
+
+// clearGameState removes the persisted snapshot for gameId once it has
+// fully settled (saved to `games` and committed), so boot recovery only
+// ever finds genuinely in-flight games. It takes an explicit id, rather
+// than using game.id, because by the time this runs commitWaiting has
+// already reserved the next game's id for any rolled-over waiting bets.
+func (game *Game) clearGameState(gameId uuid.UUID) error {
+	tx, err := game.db.Begin();
+
+	if err != nil {
+		return err;
+	}
+
+	if _, err := tx.Exec(`DELETE FROM game_state_players WHERE gameId = ?`, gameId); err != nil {
+		tx.Rollback();
+		return err;
+	}
+
+	if _, err := tx.Exec(`DELETE FROM game_state WHERE gameId = ?`, gameId); err != nil {
+		tx.Rollback();
+		return err;
+	}
+
+	return tx.Commit();
 }
 
-func (game *Game) getRecentGames(limit int) ([]CrashedGame, error) {
-	var games []CrashedGame;
+// resumeGameState inspects the last persisted game_state row (if any).
+// A game whose endTime has already passed is settled immediately
+// (winners credited, the rest refunded); one still in the future is
+// rescheduled with the remaining duration.
+func (game *Game) resumeGameState() error {
+	var (
+		gameId string;
+		state uint;
+		startTime, endTime time.Time;
+		crashMultiplier decimal.Decimal;
+		gameHash, clientSeed string;
+	);
+
+	row := game.db.QueryRow(`
+		SELECT gameId, state, startTime, endTime, crashMultiplier,
+		gameHash, clientSeed
+		FROM game_state
+		ORDER BY startTime DESC
+		LIMIT 1
+	`);
+
+	err := row.Scan(
+		&gameId,
+		&state,
+		&startTime,
+		&endTime,
+		&crashMultiplier,
+		&gameHash,
+		&clientSeed,
+	);
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil;
+	}
+
+	if err != nil {
+		return err;
+	}
+
+	if state != GAMESTATE_WAITING && state != GAMESTATE_RUNNING && state != GAMESTATE_PENDING {
+		return nil;
+	}
+
+	id, err := uuid.Parse(gameId);
+
+	if err != nil {
+		return err;
+	}
+
+	players, waiting, err := game.loadGameStatePlayers(id);
+
+	if err != nil {
+		return err;
+	}
+
+	game.id = id;
+	game.state = state;
+	game.gameHash = gameHash;
+	game.clientSeed = clientSeed;
+	game.players = players;
+	game.waiting = waiting;
+
+	// PENDING has no real startTime/duration/crashPoint yet; re-run the
+	// same step that would otherwise have fired WAIT_TIME_SECS after the
+	// crash, so the rolled-over bets get a fresh outcome instead of being
+	// judged against the previous round's stale crashPoint.
+	if state == GAMESTATE_PENDING {
+		game.createNewGameLocked();
+		return nil;
+	}
 
+	game.startTime = startTime;
+	game.endTime = endTime;
+	game.duration = endTime.Sub(startTime);
+	game.crashPoint = crashMultiplier;
+
+	if time.Now().After(game.endTime) {
+		return game.settleExpiredGame();
+	}
+
+	game.rescheduleGame();
+
+	return nil;
+}
+
+func (game *Game) loadGameStatePlayers(gameId uuid.UUID) ([]*Player, []*Player, error) {
 	rows, err := game.db.Query(`
-		SELECT id, startTime, (endTime - startTime) AS duration,
-		multiplier, playerCount, winnerCount
-		FROM games
-		ORDER BY created DESC
-		LIMIT ?
-	`, limit);
+		SELECT wallet, currency, betAmount, autoCashOut, waiting,
+		cashedOut, cashoutMultiplier, cashoutPayout, auto
+		FROM game_state_players
+		WHERE gameId = ?
+	`, gameId);
+
+	if err != nil {
+		return nil, nil, err;
+	}
+
+	defer rows.Close();
+
+	players := make([]*Player, 0);
+	waiting := make([]*Player, 0);
 
 	for rows.Next() {
-		var gameRow CrashedGame;
-
-		rows.Scan(
-			gameRow.id,
-			gameRow.startTime,
-			gameRow.duration,
-			gameRow.multiplier,
-			gameRow.players,
-			gameRow.winners,
+		var player Player;
+		var isWaiting bool;
+
+		err := rows.Scan(
+			&player.wallet,
+			&player.currency,
+			&player.betAmount,
+			&player.autoCashOut,
+			&isWaiting,
+			&player.cashOut.cashedOut,
+			&player.cashOut.multiplier,
+			&player.cashOut.payout,
+			&player.cashOut.auto,
 		);
 
-		games = append(games, gameRow);
+		if err != nil {
+			return nil, nil, err;
+		}
+
+		if isWaiting {
+			waiting = append(waiting, &player);
+		} else {
+			// Every persisted non-waiting player reached game.players
+			// either via an immediate WAITING-state bet or via
+			// commitWaiting's rollover, both of which debit the balance
+			// before appending; see the debited field's doc comment.
+			player.debited = true;
+			players = append(players, &player);
+		}
+	}
+
+	return players, waiting, nil;
+}
+
+// settleExpiredGame handles the case where the server was down for
+// longer than the game's remaining duration: the outcome is already
+// known, so winners (players whose autoCashOut would have fired before
+// the crash) are credited at their target multiplier, and everyone else
+// is refunded rather than guessed at.
+func (game *Game) settleExpiredGame() error {
+	slog.Info("Resuming past-due game; settling", "game", game.id);
+
+	game.state = GAMESTATE_CRASHED;
+
+	for _, player := range game.players {
+		if player.cashOut.cashedOut {
+			continue;
+		}
+
+		if !player.autoCashOut.Equal(decimal.Zero) && player.autoCashOut.LessThanOrEqual(game.crashPoint) {
+			payout := player.betAmount.Mul(player.autoCashOut);
+
+			if _, err := game.bank.IncreaseBalance(
+				player.wallet,
+				player.currency,
+				payout,
+				"Auto cashout (recovered)",
+				game.id,
+			); err != nil {
+				slog.Error("Failed to credit recovered win", "wallet", player.wallet, "err", err);
+			}
+
+			player.cashOut = CashOut{
+				duration: game.duration,
+				multiplier: player.autoCashOut,
+				payout: payout,
+				cashedOut: true,
+				auto: true,
+			};
+
+			if err := game.recordBetCashedOut(player); err != nil {
+				slog.Error("Failed to record recovered cashout", "wallet", player.wallet, "err", err);
+			}
+
+			continue;
+		}
+
+		if !player.debited {
+			continue;
+		}
+
+		if _, err := game.bank.RefundBet(
+			player.wallet,
+			player.currency,
+			player.betAmount,
+			"Refund (server restart mid-game)",
+			game.id,
+		); err != nil {
+			slog.Error("Failed to refund bet during recovery", "wallet", player.wallet, "err", err);
+		}
 	}
 
+	expiredId := game.id;
+
+	record, err := game.saveRecord();
+
 	if err != nil {
-		return nil, err;
+		return err;
+	}
+
+	game.commitWaiting();
+
+	if err := game.clearGameState(expiredId); err != nil {
+		return err;
+	}
+
+	game.Emit(EVENT_GAME_CRASHED, map[string]*CrashedGame{
+		"game": record,
+	});
+
+	time.AfterFunc(WAIT_TIME_SECS * time.Second, game.createNewGame);
+
+	return nil;
+}
+
+// rescheduleGame re-arms the timers for a game that was still in
+// progress when the process restarted, using the remaining duration
+// rather than the original one.
+func (game *Game) rescheduleGame() {
+	now := time.Now();
+
+	if game.state == GAMESTATE_WAITING {
+		untilStart := game.startTime.Sub(now);
+
+		if untilStart < 0 {
+			untilStart = 0;
+		}
+
+		time.AfterFunc(untilStart, game.handleGameStart);
+	}
+
+	remaining := game.endTime.Sub(now);
+
+	if remaining < 0 {
+		remaining = 0;
+	}
+
+	time.AfterFunc(remaining, game.handleGameCrash);
+
+	if game.state == GAMESTATE_RUNNING {
+		elapsed := now.Sub(game.startTime);
+
+		for i := range(game.players) {
+			if game.players[i].cashOut.cashedOut || game.players[i].autoCashOut.Equal(decimal.Zero) {
+				continue;
+			}
+
+			remainingCashOut := game.autoCashOutDelay(game.players[i].autoCashOut) - elapsed;
+
+			if remainingCashOut < 0 {
+				remainingCashOut = 0;
+			}
+
+			game.players[i].timeOut = time.AfterFunc(
+				remainingCashOut,
+				game.autoCashOutCallback(game.players[i]),
+			);
+		}
+
+		game.startTicker();
+	}
+
+	slog.Info("Resumed in-flight game", "game", game.id, "state", game.state);
+
+	game.Emit(EVENT_GAME_RESUMED, map[string]any{
+		"startTime": game.startTime.Unix(),
+		"endTime": game.endTime.Unix(),
+	});
+}
+
+func (game *Game) multiplierForDuration(duration time.Duration) decimal.Decimal {
+	durationMs := decimal.NewFromInt(duration.Milliseconds());
+	coeff := decimal.NewFromFloat(6E-5);
+	e := decimal.NewFromFloat(math.Exp(1));
+
+	return e.Pow(coeff.Mul(durationMs)).Truncate(2);
+}
+
+func (game *Game) calculatePayout(
+	duration time.Duration,
+	betAmount decimal.Decimal,
+) (decimal.Decimal, decimal.Decimal) {
+	multiplier := game.multiplierForDuration(duration);
+
+	return betAmount.Mul(multiplier), multiplier;
+}
+
+// recordBetPlaced writes a row to `bets` once a player's balance has
+// actually been taken, so historical/leaderboard queries have a
+// complete record independent of in-memory game state.
+func (game *Game) recordBetPlaced(player *Player) error {
+	betId, err := uuid.NewV7();
+
+	if err != nil {
+		return err;
 	}
 
-	return games, nil;
+	_, err = game.db.Exec(`
+		INSERT INTO bets
+		(id, gameId, wallet, currency, betAmount, auto, placedAt)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, betId, game.id, player.wallet, player.currency, player.betAmount,
+		false, time.Now());
+
+	return err;
+}
+
+// recordBetCashedOut fills in the cashout details on a player's bet
+// row, matched by (gameId, wallet). HandlePlaceBet rejects a second bet
+// only from a wallet already in game.players, not one already in the
+// waiting list, so a wallet can have several rows for a game; all of
+// them receive the same cashout details.
+func (game *Game) recordBetCashedOut(player *Player) error {
+	_, err := game.db.Exec(`
+		UPDATE bets SET cashoutMultiplier = ?, payout = ?, auto = ?
+		WHERE gameId = ? AND wallet = ?
+	`, player.cashOut.multiplier, player.cashOut.payout, player.cashOut.auto,
+		game.id, player.wallet);
+
+	return err;
 }
 
 func (game *Game) saveRecord() (*CrashedGame, error) {
 	winners := 0;
 	players := len(game.players);
+	totalBetAmount := decimal.Zero;
+	totalUsdValue := decimal.Zero;
 
 	for i := range(game.players) {
 		if game.players[i].cashOut.cashedOut {
 			winners++;
 		}
+
+		totalBetAmount = totalBetAmount.Add(game.players[i].betAmount);
+		totalUsdValue = totalUsdValue.Add(game.players[i].usdValue);
 	}
 
-	multiplier := game.calculateFinalMultiplier();
+	multiplier := game.crashPoint;
 
 	_, err := game.db.Exec(`
 		INSERT INTO games
-		(id, startTime, endTime, multiplier, playerCount, winnerCount)
+		(id, startTime, endTime, multiplier, playerCount, winnerCount,
+		gameHash, clientSeed, totalBetAmount, totalUsdValue)
 		VALUES
-		(?, ?, ?, ?, ?, ?)
+		(?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`, game.id, game.startTime, game.endTime, multiplier,
-		players, winners);
+		players, winners, game.gameHash, game.clientSeed,
+		totalBetAmount, totalUsdValue);
 
 	if err != nil {
 		return nil, err;
@@ -612,6 +1328,10 @@ func (game *Game) saveRecord() (*CrashedGame, error) {
 		multiplier: multiplier,
 		players: players,
 		winners: winners,
+		gameHash: game.gameHash,
+		clientSeed: game.clientSeed,
+		totalBetAmount: totalBetAmount,
+		totalUsdValue: totalUsdValue,
 	};
 
 	return &record, nil;
@@ -0,0 +1,50 @@
+package game;
+
+import (
+	"testing";
+
+	"github.com/shopspring/decimal";
+
+	"github.com/samott/crash-backend/priceoracle";
+);
+
+// usdValueOf is what HandlePlaceBet consults to enforce USD-normalized
+// bet limits; it must degrade gracefully rather than block betting when
+// no PriceOracle is configured or the upstream is unavailable.
+func TestUsdValueOfWithConfiguredOracle(t *testing.T) {
+	game := &Game{
+		prices: priceoracle.StaticOracle{
+			"USDT": decimal.NewFromInt(1),
+		},
+	};
+
+	usdValue, ok := game.usdValueOf("USDT", decimal.NewFromInt(50));
+
+	if !ok {
+		t.Fatalf("expected a USD value to be computed");
+	}
+
+	if !usdValue.Equal(decimal.NewFromInt(50)) {
+		t.Fatalf("expected 50, got %s", usdValue);
+	}
+}
+
+func TestUsdValueOfDegradesWithoutOracle(t *testing.T) {
+	game := &Game{};
+
+	_, ok := game.usdValueOf("USDT", decimal.NewFromInt(50));
+
+	if ok {
+		t.Fatalf("expected usdValueOf to degrade to ok=false with no oracle configured");
+	}
+}
+
+func TestUsdValueOfDegradesOnUnknownCurrency(t *testing.T) {
+	game := &Game{ prices: priceoracle.StaticOracle{} };
+
+	_, ok := game.usdValueOf("USDT", decimal.NewFromInt(50));
+
+	if ok {
+		t.Fatalf("expected usdValueOf to degrade to ok=false for an unpriced currency");
+	}
+}
@@ -0,0 +1,119 @@
+package game;
+
+import (
+	"database/sql";
+	"fmt";
+	"sync/atomic";
+	"testing";
+	"time";
+
+	"github.com/google/uuid";
+	"github.com/shopspring/decimal";
+
+	"github.com/samott/crash-backend/fairness";
+);
+
+var resumeTestDriverSeq int64;
+
+// openFakeDB registers a fresh fakeDriver under a unique name and opens
+// it, so each test gets its own isolated in-memory game_state store.
+func openFakeDB(t *testing.T) *sql.DB {
+	name := fmt.Sprintf("fakegame-%d", atomic.AddInt64(&resumeTestDriverSeq, 1));
+
+	sql.Register(name, newFakeDriver());
+
+	db, err := sql.Open(name, "");
+
+	if err != nil {
+		t.Fatalf("sql.Open: %v", err);
+	}
+
+	t.Cleanup(func() { db.Close(); });
+
+	return db;
+}
+
+// TestResumeGameStateRecoversPendingRollover covers the gap between
+// commitWaiting reserving the next game's id (with rolled-over bets
+// already debited) and createNewGameLocked giving it a real
+// startTime/duration WAIT_TIME_SECS later. A restart in that gap must
+// resume the rolled-over bet rather than discard it as an already-settled
+// game.
+func TestResumeGameStateRecoversPendingRollover(t *testing.T) {
+	db := openFakeDB(t);
+
+	oracle := &fakeCrashOracle{
+		outcome: fairness.Outcome{
+			GameHash: "next-hash",
+			CrashPoint: decimal.NewFromFloat(3),
+			Duration: time.Millisecond,
+		},
+	};
+
+	bank := fakeBank{};
+
+	crashed := &Game{
+		db: db,
+		bank: bank,
+		oracle: oracle,
+		id: mustUUID(t),
+		state: GAMESTATE_CRASHED,
+		gameHash: "prev-hash",
+		players: []*Player{},
+		waiting: []*Player{
+			{
+				wallet: "wallet-1",
+				currency: "USDT",
+				betAmount: decimal.NewFromInt(10),
+			},
+		},
+	};
+
+	// Simulates handleGameCrash rolling the waiting bets over, then the
+	// process dying before createNewGameLocked fires WAIT_TIME_SECS later.
+	crashed.commitWaiting();
+
+	if crashed.state != GAMESTATE_PENDING {
+		t.Fatalf("expected commitWaiting to leave state PENDING, got %d", crashed.state);
+	}
+
+	rolledOverId := crashed.id;
+
+	// A fresh Game, as if the process had just restarted, sharing only
+	// the persisted store.
+	resumed := &Game{
+		db: db,
+		bank: bank,
+		oracle: oracle,
+	};
+
+	if err := resumed.resumeGameState(); err != nil {
+		t.Fatalf("resumeGameState: %v", err);
+	}
+
+	if resumed.id != rolledOverId {
+		t.Fatalf("expected resumed game to keep the rolled-over id %s, got %s", rolledOverId, resumed.id);
+	}
+
+	if resumed.state != GAMESTATE_WAITING {
+		t.Fatalf("expected PENDING to resolve via createNewGameLocked into WAITING, got state %d", resumed.state);
+	}
+
+	if len(resumed.players) != 1 || resumed.players[0].wallet != "wallet-1" {
+		t.Fatalf("expected the rolled-over bet to survive the restart, got %+v", resumed.players);
+	}
+
+	if !resumed.players[0].debited {
+		t.Fatalf("expected the rolled-over bet to still be marked debited after recovery");
+	}
+}
+
+func mustUUID(t *testing.T) uuid.UUID {
+	id, err := uuid.NewV7();
+
+	if err != nil {
+		t.Fatalf("uuid.NewV7: %v", err);
+	}
+
+	return id;
+}
@@ -0,0 +1,291 @@
+package game;
+
+import (
+	"database/sql/driver";
+	"io";
+	"strings";
+	"sync";
+	"time";
+
+	"github.com/google/uuid";
+	"github.com/shopspring/decimal";
+);
+
+// fakeDriver/fakeConn back just the handful of fixed queries that
+// persistGameState/loadGameStatePlayers/resumeGameState/clearGameState
+// issue, with an in-memory map standing in for the game_state and
+// game_state_players tables. It exists purely so resumeGameState can be
+// exercised against something that behaves like a restart-durable store,
+// without pulling in a real database driver.
+type fakeDriver struct {
+	mu sync.Mutex;
+	gameState map[string]fakeGameStateRow;
+	gameStatePlayers map[string][]fakeGameStatePlayerRow;
+};
+
+type fakeGameStateRow struct {
+	gameId string;
+	state int64;
+	startTime time.Time;
+	endTime time.Time;
+	crashMultiplier string;
+	gameHash string;
+	clientSeed string;
+};
+
+type fakeGameStatePlayerRow struct {
+	wallet string;
+	currency string;
+	betAmount string;
+	autoCashOut string;
+	waiting bool;
+	cashedOut bool;
+	cashoutMultiplier string;
+	cashoutPayout string;
+	auto bool;
+};
+
+func newFakeDriver() *fakeDriver {
+	return &fakeDriver{
+		gameState: make(map[string]fakeGameStateRow),
+		gameStatePlayers: make(map[string][]fakeGameStatePlayerRow),
+	};
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{ driver: d }, nil;
+}
+
+func (d *fakeDriver) latestGameState() (fakeGameStateRow, bool) {
+	var best fakeGameStateRow;
+	found := false;
+
+	for _, row := range d.gameState {
+		if !found || row.startTime.After(best.startTime) {
+			best = row;
+			found = true;
+		}
+	}
+
+	return best, found;
+}
+
+type fakeConn struct {
+	driver *fakeDriver;
+};
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{ driver: c.driver, query: query }, nil;
+}
+
+func (c *fakeConn) Close() error {
+	return nil;
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return fakeTx{}, nil;
+}
+
+type fakeTx struct{};
+
+func (fakeTx) Commit() error { return nil; }
+func (fakeTx) Rollback() error { return nil; }
+
+type fakeStmt struct {
+	driver *fakeDriver;
+	query string;
+};
+
+func (s *fakeStmt) Close() error { return nil; }
+func (s *fakeStmt) NumInput() int { return -1; }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.driver.mu.Lock();
+	defer s.driver.mu.Unlock();
+
+	q := s.query;
+
+	switch {
+	case strings.Contains(q, "INSERT INTO game_state_players"):
+		s.driver.gameStatePlayers[args[0].(string)] = append(
+			s.driver.gameStatePlayers[args[0].(string)],
+			fakeGameStatePlayerRow{
+				wallet: args[1].(string),
+				currency: args[2].(string),
+				betAmount: args[3].(string),
+				autoCashOut: args[4].(string),
+				waiting: args[5].(bool),
+				cashedOut: args[6].(bool),
+				cashoutMultiplier: args[7].(string),
+				cashoutPayout: args[8].(string),
+				auto: args[9].(bool),
+			},
+		);
+
+	case strings.Contains(q, "DELETE FROM game_state_players"):
+		delete(s.driver.gameStatePlayers, args[0].(string));
+
+	case strings.Contains(q, "INSERT INTO game_state"):
+		gameId := args[0].(string);
+
+		s.driver.gameState[gameId] = fakeGameStateRow{
+			gameId: gameId,
+			state: args[1].(int64),
+			startTime: args[2].(time.Time),
+			endTime: args[3].(time.Time),
+			crashMultiplier: args[4].(string),
+			gameHash: args[5].(string),
+			clientSeed: args[6].(string),
+		};
+
+	case strings.Contains(q, "DELETE FROM game_state"):
+		delete(s.driver.gameState, args[0].(string));
+	}
+
+	return fakeResult{}, nil;
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.driver.mu.Lock();
+	defer s.driver.mu.Unlock();
+
+	q := s.query;
+
+	switch {
+	case strings.Contains(q, "FROM game_state_players"):
+		rows := append(
+			[]fakeGameStatePlayerRow{},
+			s.driver.gameStatePlayers[args[0].(string)]...,
+		);
+
+		return &fakeGameStatePlayerRows{ rows: rows }, nil;
+
+	case strings.Contains(q, "FROM game_state"):
+		row, found := s.driver.latestGameState();
+
+		return &fakeGameStateRows{ row: row, found: found }, nil;
+	}
+
+	return &fakeEmptyRows{}, nil;
+}
+
+type fakeResult struct{};
+
+func (fakeResult) LastInsertId() (int64, error) { return 0, nil; }
+func (fakeResult) RowsAffected() (int64, error) { return 0, nil; }
+
+type fakeGameStateRows struct {
+	row fakeGameStateRow;
+	found bool;
+	done bool;
+};
+
+func (r *fakeGameStateRows) Columns() []string {
+	return []string{
+		"gameId", "state", "startTime", "endTime",
+		"crashMultiplier", "gameHash", "clientSeed",
+	};
+}
+
+func (r *fakeGameStateRows) Close() error { return nil; }
+
+func (r *fakeGameStateRows) Next(dest []driver.Value) error {
+	if !r.found || r.done {
+		return io.EOF;
+	}
+
+	r.done = true;
+
+	dest[0] = r.row.gameId;
+	dest[1] = r.row.state;
+	dest[2] = r.row.startTime;
+	dest[3] = r.row.endTime;
+	dest[4] = r.row.crashMultiplier;
+	dest[5] = r.row.gameHash;
+	dest[6] = r.row.clientSeed;
+
+	return nil;
+}
+
+type fakeGameStatePlayerRows struct {
+	rows []fakeGameStatePlayerRow;
+	idx int;
+};
+
+func (r *fakeGameStatePlayerRows) Columns() []string {
+	return []string{
+		"wallet", "currency", "betAmount", "autoCashOut", "waiting",
+		"cashedOut", "cashoutMultiplier", "cashoutPayout", "auto",
+	};
+}
+
+func (r *fakeGameStatePlayerRows) Close() error { return nil; }
+
+func (r *fakeGameStatePlayerRows) Next(dest []driver.Value) error {
+	if r.idx >= len(r.rows) {
+		return io.EOF;
+	}
+
+	row := r.rows[r.idx];
+	r.idx++;
+
+	dest[0] = row.wallet;
+	dest[1] = row.currency;
+	dest[2] = row.betAmount;
+	dest[3] = row.autoCashOut;
+	dest[4] = row.waiting;
+	dest[5] = row.cashedOut;
+	dest[6] = row.cashoutMultiplier;
+	dest[7] = row.cashoutPayout;
+	dest[8] = row.auto;
+
+	return nil;
+}
+
+type fakeEmptyRows struct{};
+
+func (fakeEmptyRows) Columns() []string { return nil; }
+func (fakeEmptyRows) Close() error { return nil; }
+func (fakeEmptyRows) Next(dest []driver.Value) error { return io.EOF; }
+
+// fakeBank is a minimal Bank that always succeeds, for tests that only
+// care about game_state recovery, not ledger behaviour.
+type fakeBank struct{};
+
+func (fakeBank) IncreaseBalance(
+	wallet string,
+	currency string,
+	amount decimal.Decimal,
+	reason string,
+	gameId uuid.UUID,
+) (decimal.Decimal, error) {
+	return decimal.Decimal{}, nil;
+}
+
+func (fakeBank) DecreaseBalance(
+	wallet string,
+	currency string,
+	amount decimal.Decimal,
+	reason string,
+	gameId uuid.UUID,
+) (decimal.Decimal, error) {
+	return decimal.Decimal{}, nil;
+}
+
+func (fakeBank) RefundBet(
+	wallet string,
+	currency string,
+	amount decimal.Decimal,
+	reason string,
+	gameId uuid.UUID,
+) (decimal.Decimal, error) {
+	return decimal.Decimal{}, nil;
+}
+
+func (fakeBank) GetBalance(wallet string, currency string) (decimal.Decimal, error) {
+	return decimal.Decimal{}, nil;
+}
+
+func (fakeBank) GetBalances(wallet string) (map[string]decimal.Decimal, error) {
+	return map[string]decimal.Decimal{}, nil;
+}
@@ -0,0 +1,126 @@
+package priceoracle;
+
+import (
+	"encoding/json";
+	"errors";
+	"fmt";
+	"net/http";
+	"net/url";
+	"strings";
+	"sync";
+	"time";
+
+	"github.com/shopspring/decimal";
+);
+
+var ErrPriceUnavailable = errors.New("price unavailable for currency");
+
+const (
+	DefaultTTL = 30 * time.Second;
+
+	cryptoCompareURL = "https://min-api.cryptocompare.com/data/pricemulti";
+);
+
+// Oracle reports the current USD price of a currency. It is satisfied by
+// *CryptoCompareOracle; StaticOracle is available as a fake for tests or
+// as a fallback when no live feed is configured.
+type Oracle interface {
+	GetPrice(currency string) (decimal.Decimal, error);
+};
+
+// CryptoCompareOracle fetches USD prices for a fixed set of currencies
+// from CryptoCompare's batched pricemulti endpoint (one request covers
+// every configured currency) and caches the result for ttl. A GetPrice
+// call that finds the cache older than ttl fetches fresh prices
+// synchronously, blocking on that HTTP round trip.
+type CryptoCompareOracle struct {
+	client *http.Client;
+	currencies []string;
+	ttl time.Duration;
+
+	mu sync.Mutex;
+	prices map[string]decimal.Decimal;
+	fetchedAt time.Time;
+};
+
+func NewCryptoCompareOracle(currencies []string, ttl time.Duration) *CryptoCompareOracle {
+	if ttl <= 0 {
+		ttl = DefaultTTL;
+	}
+
+	return &CryptoCompareOracle{
+		client: &http.Client{ Timeout: 5 * time.Second },
+		currencies: currencies,
+		ttl: ttl,
+	};
+}
+
+func (oracle *CryptoCompareOracle) GetPrice(currency string) (decimal.Decimal, error) {
+	oracle.mu.Lock();
+	defer oracle.mu.Unlock();
+
+	if time.Since(oracle.fetchedAt) > oracle.ttl {
+		if err := oracle.refresh(); err != nil {
+			return decimal.Decimal{}, err;
+		}
+	}
+
+	price, ok := oracle.prices[strings.ToUpper(currency)];
+
+	if !ok {
+		return decimal.Decimal{}, fmt.Errorf("%w: %s", ErrPriceUnavailable, currency);
+	}
+
+	return price, nil;
+}
+
+func (oracle *CryptoCompareOracle) refresh() error {
+	query := url.Values{};
+	query.Set("fsyms", strings.Join(oracle.currencies, ","));
+	query.Set("tsyms", "USD");
+
+	resp, err := oracle.client.Get(cryptoCompareURL + "?" + query.Encode());
+
+	if err != nil {
+		return err;
+	}
+
+	defer resp.Body.Close();
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("cryptocompare: unexpected status %d", resp.StatusCode);
+	}
+
+	var body map[string]map[string]decimal.Decimal;
+
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return err;
+	}
+
+	prices := make(map[string]decimal.Decimal, len(body));
+
+	for currency, tsyms := range body {
+		if usd, ok := tsyms["USD"]; ok {
+			prices[strings.ToUpper(currency)] = usd;
+		}
+	}
+
+	oracle.prices = prices;
+	oracle.fetchedAt = time.Now();
+
+	return nil;
+}
+
+// StaticOracle is a fixed-price Oracle, useful as a fake in tests or as a
+// fallback when no live feed is configured.
+type StaticOracle map[string]decimal.Decimal;
+
+func (oracle StaticOracle) GetPrice(currency string) (decimal.Decimal, error) {
+	price, ok := oracle[strings.ToUpper(currency)];
+
+	if !ok {
+		return decimal.Decimal{}, fmt.Errorf("%w: %s", ErrPriceUnavailable, currency);
+	}
+
+	return price, nil;
+}
@@ -0,0 +1,34 @@
+package priceoracle;
+
+import (
+	"errors";
+	"testing";
+
+	"github.com/shopspring/decimal";
+);
+
+func TestStaticOracleGetPrice(t *testing.T) {
+	oracle := StaticOracle{
+		"BTC": decimal.NewFromInt(60000),
+	};
+
+	price, err := oracle.GetPrice("btc");
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err);
+	}
+
+	if !price.Equal(decimal.NewFromInt(60000)) {
+		t.Fatalf("expected 60000, got %s", price);
+	}
+}
+
+func TestStaticOracleGetPriceUnknownCurrency(t *testing.T) {
+	oracle := StaticOracle{};
+
+	_, err := oracle.GetPrice("ETH");
+
+	if !errors.Is(err, ErrPriceUnavailable) {
+		t.Fatalf("expected ErrPriceUnavailable, got %v", err);
+	}
+}